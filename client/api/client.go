@@ -7,8 +7,10 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -50,7 +52,7 @@ func (c *Client) ProcessAudioFile(audioPath string, screenshotPath string) (*Pro
 	}
 	defer audioFile.Close()
 
-	audioWriter, err := writer.CreateFormFile("audio", filepath.Base(audioPath))
+	audioWriter, err := createFormFile(writer, "audio", filepath.Base(audioPath), audioContentType(audioPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create audio form field: %w", err)
 	}
@@ -183,6 +185,30 @@ func (c *Client) ProcessAudio(audioData []byte, screenshotData []byte) (*Process
 	return &processResp, nil
 }
 
+// audioContentType returns the MIME type to advertise for an audio file
+// based on its extension, so compressed uploads (MP3/Opus) aren't sent as
+// generic octet-streams.
+func audioContentType(audioPath string) string {
+	switch strings.ToLower(filepath.Ext(audioPath)) {
+	case ".mp3":
+		return "audio/mpeg"
+	case ".opus":
+		return "audio/opus"
+	default:
+		return "audio/wav"
+	}
+}
+
+// createFormFile is like multipart.Writer.CreateFormFile but lets the
+// caller set the part's Content-Type instead of defaulting to
+// application/octet-stream.
+func createFormFile(writer *multipart.Writer, fieldName, fileName, contentType string) (io.Writer, error) {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, fieldName, fileName))
+	header.Set("Content-Type", contentType)
+	return writer.CreatePart(header)
+}
+
 // HealthCheck performs a health check on the API
 func (c *Client) HealthCheck() error {
 	resp, err := c.httpClient.Get(c.baseURL + "/")
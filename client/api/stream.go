@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// endOfStreamSeq marks the final frame of a StreamSession; the server
+// treats it as a signal to flush and close out the transcription.
+const endOfStreamSeq = ^uint32(0)
+
+// StreamSession is an open chunked upload opened by StreamAudio. Segments
+// are forwarded as they are produced instead of waiting for a full
+// recording, and transcript deltas are read back over the same
+// connection.
+type StreamSession struct {
+	pw      *io.PipeWriter
+	deltas  chan string
+	done    chan error
+	nextSeq uint32
+
+	// writeMu serializes frame writes so a caller's SendSegment can't
+	// interleave with Close's end-of-stream frame on the underlying pipe.
+	writeMu sync.Mutex
+}
+
+// StreamAudio opens a chunked HTTP POST that encoded audio segments can
+// be forwarded to as they arrive, instead of buffering a full recording
+// before uploading it.
+func (c *Client) StreamAudio(ctx context.Context) (*StreamSession, error) {
+	pr, pw := io.Pipe()
+
+	url := c.baseURL + "/agents/audio-transcription-agent/stream"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	session := &StreamSession{
+		pw:     pw,
+		deltas: make(chan string, 32),
+		done:   make(chan error, 1),
+	}
+
+	go func() {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			session.done <- fmt.Errorf("stream request failed: %w", err)
+			close(session.deltas)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			session.done <- fmt.Errorf("server returned status %d", resp.StatusCode)
+			close(session.deltas)
+			return
+		}
+		session.readDeltas(resp.Body)
+	}()
+
+	return session, nil
+}
+
+// Deltas streams transcript deltas as the backend produces them.
+func (s *StreamSession) Deltas() <-chan string {
+	return s.deltas
+}
+
+// readDeltas copies newline-delimited transcript deltas from the
+// response body into s.deltas until the connection closes.
+func (s *StreamSession) readDeltas(body io.ReadCloser) {
+	defer body.Close()
+	defer close(s.deltas)
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		s.deltas <- scanner.Text()
+	}
+	s.done <- scanner.Err()
+}
+
+// SendSegment forwards one encoded audio segment, tagged with a
+// monotonically increasing sequence number so the server can reassemble
+// segments in order and detect gaps.
+func (s *StreamSession) SendSegment(data []byte) error {
+	seq := s.nextSeq
+	s.nextSeq++
+	return s.writeFrame(seq, data)
+}
+
+// Close sends the end-of-stream sentinel, waits for the backend to
+// finish draining transcript deltas, and releases the connection.
+func (s *StreamSession) Close() error {
+	if err := s.writeFrame(endOfStreamSeq, nil); err != nil {
+		s.pw.CloseWithError(err)
+		return err
+	}
+	if err := s.pw.Close(); err != nil {
+		return fmt.Errorf("failed to close stream: %w", err)
+	}
+	return <-s.done
+}
+
+// writeFrame writes a single [seq uint32][length uint32][data] frame.
+func (s *StreamSession) writeFrame(seq uint32, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := binary.Write(s.pw, binary.LittleEndian, seq); err != nil {
+		return fmt.Errorf("failed to write segment sequence: %w", err)
+	}
+	if err := binary.Write(s.pw, binary.LittleEndian, uint32(len(data))); err != nil {
+		return fmt.Errorf("failed to write segment length: %w", err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if _, err := s.pw.Write(data); err != nil {
+		return fmt.Errorf("failed to write segment data: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/chtushar/swear-by-sheets/client/audio/loudness"
 	"github.com/gordonklaus/portaudio"
 )
 
@@ -16,24 +17,107 @@ const (
 	sampleRate      = 44100
 	channels        = 1
 	framesPerBuffer = 1024
+
+	// segmentDuration is the length of each chunk emitted on Segments.
+	segmentDuration = 1500 * time.Millisecond
+	// segmentQueueCap bounds the Segments channel; once full, the oldest
+	// unconsumed segment is dropped so a stalled consumer can't block
+	// recording.
+	segmentQueueCap = 100
+
+	// defaultNormalizeTo is the EBU R128-recommended target for speech.
+	defaultNormalizeTo = -16.0
 )
 
+// segmentSamples is the number of int16 samples per emitted segment.
+var segmentSamples = int(segmentDuration.Seconds() * float64(sampleRate*channels))
+
 // Recorder handles audio recording
 type Recorder struct {
-	stream    *portaudio.Stream
-	buffer    []int16
-	recording bool
-	mu        sync.Mutex
-	stopChan  chan bool
-	wg        sync.WaitGroup
+	stream          *portaudio.Stream
+	buffer          []int16
+	segmentBuf      []int16
+	segmentCh       chan []int16
+	segmentsEnabled bool
+	recording       bool
+	codec           Codec
+	mu              sync.Mutex
+	stopChan        chan bool
+	wg              sync.WaitGroup
+
+	vadEnabled   bool
+	vadCfg       VADConfig
+	vadSpeaking  bool
+	vadSilenceMs float64
+	vadPad       []int16
+	vadEvents    chan SpeechEvent
+
+	// NormalizeTo is the target integrated loudness (LUFS) SaveToFile
+	// normalizes recordings to before encoding. Zero disables
+	// normalization.
+	NormalizeTo float64
 }
 
 // NewRecorder creates a new audio recorder
 func NewRecorder() *Recorder {
 	return &Recorder{
-		buffer:   make([]int16, 0),
-		stopChan: make(chan bool),
+		buffer:      make([]int16, 0),
+		segmentCh:   make(chan []int16, segmentQueueCap),
+		stopChan:    make(chan bool),
+		codec:       CodecWAV,
+		NormalizeTo: defaultNormalizeTo,
+	}
+}
+
+// Segments returns a channel of fixed-duration (~1.5s) PCM chunks emitted
+// as they are captured, for callers that want to stream audio instead of
+// waiting for StopRecording. If the channel fills up because the consumer
+// falls behind, the oldest queued segment is dropped to make room.
+func (r *Recorder) Segments() <-chan []int16 {
+	return r.segmentCh
+}
+
+// SetSegmentsEnabled controls whether captured audio is also accumulated
+// into fixed-duration chunks on Segments. It defaults to disabled, so
+// callers that never read Segments don't pay for copying and buffering
+// audio nothing will drain.
+func (r *Recorder) SetSegmentsEnabled(enabled bool) {
+	r.mu.Lock()
+	r.segmentsEnabled = enabled
+	r.mu.Unlock()
+}
+
+// SetCodec sets the codec used by SaveToFile. It defaults to CodecWAV.
+func (r *Recorder) SetCodec(codec Codec) error {
+	if _, err := NewEncoder(codec); err != nil {
+		return err
 	}
+	r.mu.Lock()
+	r.codec = codec
+	r.mu.Unlock()
+	return nil
+}
+
+// Codec returns the currently configured codec.
+func (r *Recorder) Codec() Codec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.codec
+}
+
+// SampleRate returns the fixed capture sample rate used by the recorder.
+func (r *Recorder) SampleRate() int { return sampleRate }
+
+// Channels returns the fixed channel count used by the recorder.
+func (r *Recorder) Channels() int { return channels }
+
+// Extension returns the file extension produced by the current codec.
+func (r *Recorder) Extension() string {
+	r.mu.Lock()
+	codec := r.codec
+	r.mu.Unlock()
+	enc, _ := NewEncoder(codec)
+	return enc.Extension()
 }
 
 // StartRecording begins audio capture
@@ -44,7 +128,11 @@ func (r *Recorder) StartRecording() error {
 		return fmt.Errorf("already recording")
 	}
 	r.recording = true
-	r.buffer = make([]int16, 0) // Clear buffer
+	r.buffer = make([]int16, 0)     // Clear buffer
+	r.segmentBuf = make([]int16, 0) // Clear segment accumulator
+	r.vadSpeaking = false
+	r.vadSilenceMs = 0
+	r.vadPad = nil
 	r.mu.Unlock()
 
 	// Initialize PortAudio
@@ -94,6 +182,14 @@ func (r *Recorder) StopRecording() error {
 	close(r.stopChan)
 	r.wg.Wait()
 
+	// Flush any partial segment shorter than segmentSamples
+	r.mu.Lock()
+	if len(r.segmentBuf) > 0 {
+		r.emitSegment(r.segmentBuf)
+		r.segmentBuf = r.segmentBuf[:0]
+	}
+	r.mu.Unlock()
+
 	// Stop and close stream
 	if r.stream != nil {
 		if err := r.stream.Stop(); err != nil {
@@ -115,32 +211,68 @@ func (r *Recorder) StopRecording() error {
 	return nil
 }
 
-// SaveToFile saves the recorded audio as a WAV file
+// SaveToFile encodes the recorded audio with the configured codec and saves
+// it to filename. Use Extension to determine the matching file extension.
 func (r *Recorder) SaveToFile(filename string) error {
+	r.mu.Lock()
+	samples := r.buffer
+	codec := r.codec
+	normalizeTo := r.NormalizeTo
+	r.mu.Unlock()
+
+	return r.encodeToFile(samples, codec, normalizeTo, filename)
+}
+
+// TakeUtterance detaches and returns the samples accumulated since the
+// last call (or since StartRecording), resetting the buffer so a caller
+// keeping the stream open across multiple utterances - e.g. hands-free
+// mode - can save/encode each one independently.
+func (r *Recorder) TakeUtterance() []int16 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if len(r.buffer) == 0 {
+	samples := r.buffer
+	r.buffer = make([]int16, 0)
+	return samples
+}
+
+// SaveSamplesToFile encodes samples (e.g. from TakeUtterance) with the
+// configured codec and saves them to filename, without touching the
+// recorder's own buffer.
+func (r *Recorder) SaveSamplesToFile(samples []int16, filename string) error {
+	r.mu.Lock()
+	codec := r.codec
+	normalizeTo := r.NormalizeTo
+	r.mu.Unlock()
+
+	return r.encodeToFile(samples, codec, normalizeTo, filename)
+}
+
+// encodeToFile normalizes (if configured) and encodes samples with codec,
+// writing the result to filename.
+func (r *Recorder) encodeToFile(samples []int16, codec Codec, normalizeTo float64, filename string) error {
+	if len(samples) == 0 {
 		return fmt.Errorf("no audio data to save")
 	}
 
+	enc, err := NewEncoder(codec)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %v", err)
+	}
+
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %v", err)
 	}
 	defer file.Close()
 
-	// Write WAV header
-	if err := r.writeWAVHeader(file, len(r.buffer)*2); err != nil {
-		return fmt.Errorf("failed to write WAV header: %v", err)
+	if normalizeTo != 0 {
+		samples = loudness.Normalize(samples, normalizeTo, sampleRate)
 	}
 
-	// Write audio data
 	buf := new(bytes.Buffer)
-	for _, sample := range r.buffer {
-		if err := binary.Write(buf, binary.LittleEndian, sample); err != nil {
-			return fmt.Errorf("failed to write audio data: %v", err)
-		}
+	if err := enc.Encode(buf, samples, sampleRate, channels); err != nil {
+		return fmt.Errorf("failed to encode audio: %v", err)
 	}
 
 	if _, err := file.Write(buf.Bytes()); err != nil {
@@ -155,8 +287,52 @@ func (r *Recorder) processAudio(in []int16) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.recording {
-		r.buffer = append(r.buffer, in...)
+	if !r.recording {
+		return
+	}
+
+	if r.vadEnabled {
+		r.processVADFrame(in)
+		return
+	}
+
+	r.appendSamples(in)
+}
+
+// appendSamples adds samples to the full recording buffer and the
+// segment accumulator, emitting segments as they fill. Callers must hold
+// r.mu.
+func (r *Recorder) appendSamples(samples []int16) {
+	r.buffer = append(r.buffer, samples...)
+
+	if !r.segmentsEnabled {
+		return
+	}
+
+	r.segmentBuf = append(r.segmentBuf, samples...)
+	for len(r.segmentBuf) >= segmentSamples {
+		r.emitSegment(r.segmentBuf[:segmentSamples])
+		r.segmentBuf = r.segmentBuf[segmentSamples:]
+	}
+}
+
+// emitSegment enqueues seg on segmentCh, dropping the oldest queued
+// segment first if the channel is full. Callers must hold r.mu.
+func (r *Recorder) emitSegment(seg []int16) {
+	segCopy := make([]int16, len(seg))
+	copy(segCopy, seg)
+
+	select {
+	case r.segmentCh <- segCopy:
+	default:
+		select {
+		case <-r.segmentCh:
+		default:
+		}
+		select {
+		case r.segmentCh <- segCopy:
+		default:
+		}
 	}
 }
 
@@ -167,7 +343,7 @@ func (r *Recorder) recordingLoop() {
 }
 
 // writeWAVHeader writes a WAV file header
-func (r *Recorder) writeWAVHeader(w io.Writer, dataSize int) error {
+func writeWAVHeader(w io.Writer, dataSize, sampleRate, channels int) error {
 	// RIFF header
 	if _, err := w.Write([]byte("RIFF")); err != nil {
 		return err
@@ -0,0 +1,184 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/hraban/opus"
+	lame "github.com/sunicy/go-lame"
+)
+
+// Codec identifies an audio encoding target.
+type Codec string
+
+const (
+	// CodecWAV writes uncompressed 16-bit PCM.
+	CodecWAV Codec = "wav"
+	// CodecMP3 writes MP3 via libmp3lame.
+	CodecMP3 Codec = "mp3"
+	// CodecOpus writes voice-optimized Opus at 24kbps mono.
+	CodecOpus Codec = "opus"
+)
+
+// opusBitrate is tuned for voice commands, not music.
+const opusBitrate = 24000
+
+// opusSampleRate is the libopus-supported rate audio is resampled to
+// before encoding. The recorder always captures at 44100Hz, which isn't
+// one of libopus's accepted rates (8000/12000/16000/24000/48000).
+const opusSampleRate = 48000
+
+// Encoder encodes raw PCM samples into a specific audio codec.
+type Encoder interface {
+	// Encode writes the encoded representation of samples to w.
+	Encode(w io.Writer, samples []int16, sampleRate, channels int) error
+	// Extension is the file extension (without dot) this encoder produces.
+	Extension() string
+	// ContentType is the MIME type to use when uploading the encoded audio.
+	ContentType() string
+}
+
+// NewEncoder returns the Encoder for the given codec.
+func NewEncoder(codec Codec) (Encoder, error) {
+	switch codec {
+	case CodecWAV, "":
+		return wavEncoder{}, nil
+	case CodecMP3:
+		return mp3Encoder{}, nil
+	case CodecOpus:
+		return opusEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported audio codec: %q", codec)
+	}
+}
+
+// wavEncoder writes uncompressed 16-bit PCM WAV, matching writeWAVHeader.
+type wavEncoder struct{}
+
+func (wavEncoder) Encode(w io.Writer, samples []int16, sampleRate, channels int) error {
+	if err := writeWAVHeader(w, len(samples)*2, sampleRate, channels); err != nil {
+		return fmt.Errorf("failed to write WAV header: %v", err)
+	}
+	for _, sample := range samples {
+		if err := binary.Write(w, binary.LittleEndian, sample); err != nil {
+			return fmt.Errorf("failed to write audio data: %v", err)
+		}
+	}
+	return nil
+}
+
+func (wavEncoder) Extension() string   { return "wav" }
+func (wavEncoder) ContentType() string { return "audio/wav" }
+
+// mp3Encoder wraps go-lame for MP3 output.
+type mp3Encoder struct{}
+
+func (mp3Encoder) Encode(w io.Writer, samples []int16, sampleRate, channels int) error {
+	enc, err := lame.NewEncoder(w, &lame.EncoderConfig{
+		SampleRate: sampleRate,
+		Channels:   channels,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MP3 encoder: %v", err)
+	}
+	defer enc.Close()
+
+	buf := make([]byte, len(samples)*2)
+	for i, sample := range samples {
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(sample))
+	}
+	if _, err := enc.Write(buf); err != nil {
+		return fmt.Errorf("failed to encode MP3 data: %v", err)
+	}
+	return nil
+}
+
+func (mp3Encoder) Extension() string   { return "mp3" }
+func (mp3Encoder) ContentType() string { return "audio/mpeg" }
+
+// opusEncoder wraps go-opus for voice-optimized Opus output.
+type opusEncoder struct{}
+
+func (opusEncoder) Encode(w io.Writer, samples []int16, sampleRate, channels int) error {
+	inputSampleRate := sampleRate
+	if sampleRate != opusSampleRate {
+		samples = resampleLinear(samples, sampleRate, opusSampleRate, channels)
+		sampleRate = opusSampleRate
+	}
+
+	enc, err := opus.NewEncoder(sampleRate, channels, opus.AppVoIP)
+	if err != nil {
+		return fmt.Errorf("failed to create Opus encoder: %v", err)
+	}
+	if err := enc.SetBitrate(opusBitrate); err != nil {
+		return fmt.Errorf("failed to set Opus bitrate: %v", err)
+	}
+
+	ogg := newOggOpusWriter(w)
+	if err := ogg.WriteHeaders(channels, inputSampleRate); err != nil {
+		return fmt.Errorf("failed to write Ogg Opus headers: %v", err)
+	}
+
+	// Opus requires fixed frame sizes (20ms); encode in chunks and pad the
+	// final partial frame with silence. Each encoded packet is wrapped in
+	// its own Ogg page so the result is a standard Ogg Opus file.
+	frameSize := sampleRate / 50 * channels
+	frameSamplesPerChannel := frameSize / channels
+	out := make([]byte, 4000)
+	for offset := 0; offset < len(samples); offset += frameSize {
+		end := offset + frameSize
+		frame := make([]int16, frameSize)
+		if end > len(samples) {
+			end = len(samples)
+		}
+		copy(frame, samples[offset:end])
+
+		n, err := enc.Encode(frame, out)
+		if err != nil {
+			return fmt.Errorf("failed to encode Opus frame: %v", err)
+		}
+		last := offset+frameSize >= len(samples)
+		if err := ogg.WritePacket(out[:n], frameSamplesPerChannel, last); err != nil {
+			return fmt.Errorf("failed to write Ogg Opus page: %v", err)
+		}
+	}
+	return nil
+}
+
+// resampleLinear converts samples from fromRate to toRate using linear
+// interpolation between frames, independently per channel.
+func resampleLinear(samples []int16, fromRate, toRate, channels int) []int16 {
+	if fromRate == toRate || len(samples) == 0 || channels <= 0 {
+		return samples
+	}
+
+	frames := len(samples) / channels
+	outFrames := int(float64(frames) * float64(toRate) / float64(fromRate))
+	out := make([]int16, outFrames*channels)
+
+	for i := 0; i < outFrames; i++ {
+		srcPos := float64(i) * float64(fromRate) / float64(toRate)
+		srcIdx := int(srcPos)
+		frac := srcPos - float64(srcIdx)
+
+		for c := 0; c < channels; c++ {
+			s0 := resampleFrame(samples, srcIdx, c, channels, frames)
+			s1 := resampleFrame(samples, srcIdx+1, c, channels, frames)
+			out[i*channels+c] = int16(float64(s0) + (float64(s1)-float64(s0))*frac)
+		}
+	}
+	return out
+}
+
+// resampleFrame returns the sample for channel c at frameIdx, clamping to
+// the last frame instead of reading out of bounds.
+func resampleFrame(samples []int16, frameIdx, c, channels, frames int) int16 {
+	if frameIdx >= frames {
+		frameIdx = frames - 1
+	}
+	return samples[frameIdx*channels+c]
+}
+
+func (opusEncoder) Extension() string   { return "opus" }
+func (opusEncoder) ContentType() string { return "audio/opus" }
@@ -0,0 +1,78 @@
+package loudness
+
+import "math"
+
+// biquad is a second-order IIR section in direct form 1, used to apply
+// the two stages of BS.1770 K-weighting.
+type biquad struct {
+	b0, b1, b2 float64
+	a1, a2     float64
+
+	x1, x2 float64
+	y1, y2 float64
+}
+
+func (f *biquad) process(samples []float64) []float64 {
+	out := make([]float64, len(samples))
+	for i, x := range samples {
+		y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+		f.x2, f.x1 = f.x1, x
+		f.y2, f.y1 = f.y1, y
+		out[i] = y
+	}
+	return out
+}
+
+// kWeight applies the BS.1770 K-weighting filter: a high-shelf pre-filter
+// (simulating head diffraction) followed by an RLB high-pass (simulating
+// the ear's reduced sensitivity to low frequencies).
+func kWeight(samples []float64, sampleRate int) []float64 {
+	stage1 := preFilter(sampleRate)
+	stage2 := rlbFilter(sampleRate)
+	return stage2.process(stage1.process(samples))
+}
+
+// preFilter returns the BS.1770 stage-1 high-shelf filter, coefficients
+// derived via the bilinear transform at the given sample rate.
+func preFilter(sampleRate int) *biquad {
+	const (
+		f0 = 1681.9744509555319
+		g  = 3.99984385397
+		q  = 0.7071752369554193
+	)
+
+	fs := float64(sampleRate)
+	k := math.Tan(math.Pi * f0 / fs)
+	vh := math.Pow(10, g/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+
+	a0 := 1.0 + k/q + k*k
+	return &biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2.0 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2.0 * (k*k - 1.0) / a0,
+		a2: (1.0 - k/q + k*k) / a0,
+	}
+}
+
+// rlbFilter returns the BS.1770 stage-2 "Revised Low-frequency B" high-
+// pass filter.
+func rlbFilter(sampleRate int) *biquad {
+	const (
+		f0 = 38.13547087613982
+		q  = 0.5003270373238773
+	)
+
+	fs := float64(sampleRate)
+	k := math.Tan(math.Pi * f0 / fs)
+	a0 := 1.0 + k/q + k*k
+
+	return &biquad{
+		b0: 1.0,
+		b1: -2.0,
+		b2: 1.0,
+		a1: 2.0 * (k*k - 1.0) / a0,
+		a2: (1.0 - k/q + k*k) / a0,
+	}
+}
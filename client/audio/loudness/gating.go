@@ -0,0 +1,147 @@
+package loudness
+
+import (
+	"math"
+	"sort"
+)
+
+// blockLoudness measures mean-square power over sliding windows of
+// windowSec with 75% overlap, returning each window's loudness in LKFS.
+func blockLoudness(filtered []float64, sampleRate int, windowSec float64) []float64 {
+	windowSamples := int(windowSec * float64(sampleRate))
+	if windowSamples <= 0 || len(filtered) < windowSamples {
+		return nil
+	}
+	step := int(float64(windowSamples) * (1 - blockOverlap))
+	if step <= 0 {
+		step = 1
+	}
+
+	var loudnesses []float64
+	for start := 0; start+windowSamples <= len(filtered); start += step {
+		window := filtered[start : start+windowSamples]
+
+		var sumSquares float64
+		for _, s := range window {
+			sumSquares += (s / fullScale) * (s / fullScale)
+		}
+		meanSquare := sumSquares / float64(windowSamples)
+		if meanSquare <= 0 {
+			continue
+		}
+		loudnesses = append(loudnesses, -0.691+10*math.Log10(meanSquare))
+	}
+	return loudnesses
+}
+
+// integratedLoudness applies the BS.1770-4 two-stage gating to 400ms
+// blocks and returns the resulting integrated loudness in LUFS.
+func integratedLoudness(filtered []float64, sampleRate int) float64 {
+	blocks := blockLoudness(filtered, sampleRate, blockDurationSec)
+	if len(blocks) == 0 {
+		return math.Inf(-1)
+	}
+
+	absoluteGated := filterAbove(blocks, absoluteGateLUFS)
+	if len(absoluteGated) == 0 {
+		return math.Inf(-1)
+	}
+
+	relativeThreshold := meanLUFS(absoluteGated) + relativeGateLU
+	relativeGated := filterAbove(absoluteGated, relativeThreshold)
+	if len(relativeGated) == 0 {
+		return math.Inf(-1)
+	}
+
+	return meanLUFS(relativeGated)
+}
+
+// loudnessRange implements the EBU Tech 3342 loudness range (LRA):
+// 3s short-term blocks, gated at -70 LUFS absolute and -20 LU relative,
+// then the 10th-to-95th percentile spread of the surviving blocks.
+func loudnessRange(filtered []float64, sampleRate int) float64 {
+	const (
+		shortTermSec = 3.0
+		relativeGate = -20.0
+	)
+
+	blocks := blockLoudness(filtered, sampleRate, shortTermSec)
+	absoluteGated := filterAbove(blocks, absoluteGateLUFS)
+	if len(absoluteGated) == 0 {
+		return 0
+	}
+
+	relativeThreshold := meanLUFS(absoluteGated) + relativeGate
+	gated := filterAbove(absoluteGated, relativeThreshold)
+	if len(gated) < 2 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), gated...)
+	sort.Float64s(sorted)
+	low := percentile(sorted, 0.10)
+	high := percentile(sorted, 0.95)
+	return high - low
+}
+
+// truePeakDBFS estimates the true (inter-sample) peak by linearly
+// oversampling 4x before measuring the peak magnitude.
+func truePeakDBFS(samples []float64) float64 {
+	if len(samples) == 0 {
+		return math.Inf(-1)
+	}
+
+	const oversample = 4
+	peak := 0.0
+	for i := 0; i < len(samples)-1; i++ {
+		for j := 0; j < oversample; j++ {
+			frac := float64(j) / float64(oversample)
+			interp := samples[i] + (samples[i+1]-samples[i])*frac
+			if abs := math.Abs(interp); abs > peak {
+				peak = abs
+			}
+		}
+	}
+	if abs := math.Abs(samples[len(samples)-1]); abs > peak {
+		peak = abs
+	}
+	if peak == 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(peak/fullScale)
+}
+
+func filterAbove(values []float64, threshold float64) []float64 {
+	var out []float64
+	for _, v := range values {
+		if v >= threshold {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// meanLUFS averages gated blocks in the power domain, then converts back
+// to LUFS, per the BS.1770-4 integration formula.
+func meanLUFS(blocksLUFS []float64) float64 {
+	var sum float64
+	for _, l := range blocksLUFS {
+		sum += math.Pow(10, (l+0.691)/10)
+	}
+	mean := sum / float64(len(blocksLUFS))
+	return -0.691 + 10*math.Log10(mean)
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	idx := p * float64(len(sorted)-1)
+	lo := int(math.Floor(idx))
+	hi := int(math.Ceil(idx))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := idx - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
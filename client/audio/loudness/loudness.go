@@ -0,0 +1,103 @@
+// Package loudness implements ITU-R BS.1770-4 integrated loudness
+// measurement and gain-based normalization for 16-bit PCM audio.
+package loudness
+
+import "math"
+
+const (
+	// blockDurationSec and blockOverlap define the 400ms/75%-overlap
+	// gating blocks required by BS.1770-4 for integrated loudness.
+	blockDurationSec = 0.4
+	blockOverlap     = 0.75
+
+	// absoluteGateLUFS discards blocks quieter than this before computing
+	// the relative gate.
+	absoluteGateLUFS = -70.0
+	// relativeGateLU is subtracted from the (absolute-gated) mean loudness
+	// to form the second, relative gate.
+	relativeGateLU = -10.0
+
+	// maxTruePeakDBFS is the ceiling Normalize clips to after applying
+	// gain, leaving 1 dB of true-peak headroom.
+	maxTruePeakDBFS = -1.0
+
+	fullScale = 32768.0
+)
+
+// Result holds the measurements produced by Measure.
+type Result struct {
+	// IntegratedLUFS is the BS.1770-4 gated integrated loudness.
+	IntegratedLUFS float64
+	// TruePeakDBFS is an oversampled estimate of the true peak level.
+	TruePeakDBFS float64
+	// LoudnessRange is the EBU Tech 3342 loudness range (LRA) in LU.
+	LoudnessRange float64
+}
+
+// Measure computes integrated loudness, true peak and loudness range for
+// samples captured at sampleRate.
+func Measure(samples []int16, sampleRate int) Result {
+	floats := toFloat64(samples)
+	filtered := kWeight(floats, sampleRate)
+
+	return Result{
+		IntegratedLUFS: integratedLoudness(filtered, sampleRate),
+		TruePeakDBFS:   truePeakDBFS(floats),
+		LoudnessRange:  loudnessRange(filtered, sampleRate),
+	}
+}
+
+// Normalize applies a scalar gain so samples' integrated loudness matches
+// targetLUFS, clipping the gain so the true peak stays below
+// maxTruePeakDBFS.
+func Normalize(samples []int16, targetLUFS float64, sampleRate int) []int16 {
+	if len(samples) == 0 {
+		return samples
+	}
+
+	current := Measure(samples, sampleRate).IntegratedLUFS
+	if math.IsInf(current, -1) {
+		// Silence: nothing to normalize against.
+		return samples
+	}
+
+	gainDB := targetLUFS - current
+	gain := math.Pow(10, gainDB/20)
+
+	floats := toFloat64(samples)
+
+	// truePeakDBFS scales linearly with gain, so the peak at unity gain
+	// plus the gain in dB gives the gained signal's true peak without
+	// rescaling and re-oversampling the whole buffer.
+	unityPeakDBFS := truePeakDBFS(floats)
+	if !math.IsInf(unityPeakDBFS, -1) {
+		gainedPeakDBFS := unityPeakDBFS + 20*math.Log10(gain)
+		if gainedPeakDBFS > maxTruePeakDBFS {
+			gain *= math.Pow(10, (maxTruePeakDBFS-gainedPeakDBFS)/20)
+		}
+	}
+
+	out := make([]int16, len(samples))
+	for i, s := range floats {
+		out[i] = clampInt16(s * gain)
+	}
+	return out
+}
+
+func clampInt16(v float64) int16 {
+	if v > 32767 {
+		return 32767
+	}
+	if v < -32768 {
+		return -32768
+	}
+	return int16(v)
+}
+
+func toFloat64(samples []int16) []float64 {
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		out[i] = float64(s)
+	}
+	return out
+}
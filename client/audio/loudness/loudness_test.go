@@ -0,0 +1,91 @@
+package loudness
+
+import (
+	"math"
+	"testing"
+)
+
+// sineInt16 generates n samples of a full-cycle sine at freq Hz and the
+// given zero-to-peak amplitude, sampled at sampleRate.
+func sineInt16(n int, freq, amp float64, sampleRate int) []int16 {
+	samples := make([]int16, n)
+	for i := range samples {
+		samples[i] = int16(amp * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+	}
+	return samples
+}
+
+func TestMeasureIntegratedLUFS(t *testing.T) {
+	const sampleRate = 48000
+
+	tests := []struct {
+		name     string
+		ampDBFS  float64
+		wantLUFS float64
+	}{
+		// Reference values from the EBU R128 / ITU-R BS.1770 conformance
+		// test set: a 997Hz sine at ampDBFS full-scale amplitude measures
+		// wantLUFS integrated loudness.
+		{"full scale 997Hz", 0, -3.01},
+		{"-20dBFS 997Hz", -20, -23.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amp := 32767.0 * math.Pow(10, tt.ampDBFS/20)
+			samples := sineInt16(sampleRate*2, 997, amp, sampleRate)
+
+			got := Measure(samples, sampleRate).IntegratedLUFS
+			if math.Abs(got-tt.wantLUFS) > 0.1 {
+				t.Errorf("IntegratedLUFS = %v, want %v ± 0.1", got, tt.wantLUFS)
+			}
+		})
+	}
+}
+
+func TestMeasureSilenceIsNegativeInfinity(t *testing.T) {
+	const sampleRate = 48000
+	silence := make([]int16, sampleRate*2)
+
+	res := Measure(silence, sampleRate)
+	if !math.IsInf(res.IntegratedLUFS, -1) {
+		t.Errorf("IntegratedLUFS for silence = %v, want -Inf", res.IntegratedLUFS)
+	}
+	if !math.IsInf(res.TruePeakDBFS, -1) {
+		t.Errorf("TruePeakDBFS for silence = %v, want -Inf", res.TruePeakDBFS)
+	}
+}
+
+func TestNormalizeReachesTargetLoudness(t *testing.T) {
+	const sampleRate = 48000
+	amp := 32767.0 * math.Pow(10, -20.0/20)
+	samples := sineInt16(sampleRate*2, 997, amp, sampleRate)
+
+	const target = -16.0
+	normalized := Normalize(samples, target, sampleRate)
+
+	got := Measure(normalized, sampleRate).IntegratedLUFS
+	if math.Abs(got-target) > 0.1 {
+		t.Errorf("IntegratedLUFS after Normalize(target=%v) = %v, want within 0.1 LU", target, got)
+	}
+}
+
+func TestNormalizeClipsToTruePeakCeiling(t *testing.T) {
+	const sampleRate = 48000
+	// A full-scale sine already sits at the true-peak ceiling, so
+	// Normalize to a loud target must clip gain rather than push the
+	// true peak above maxTruePeakDBFS.
+	samples := sineInt16(sampleRate*2, 997, 32767, sampleRate)
+
+	normalized := Normalize(samples, 0, sampleRate)
+	peak := Measure(normalized, sampleRate).TruePeakDBFS
+	if peak > maxTruePeakDBFS+0.05 {
+		t.Errorf("TruePeakDBFS after Normalize = %v, want <= %v", peak, maxTruePeakDBFS)
+	}
+}
+
+func TestNormalizeEmptyInput(t *testing.T) {
+	if got := Normalize(nil, -16, 48000); len(got) != 0 {
+		t.Errorf("Normalize(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,139 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// opusStreamSerial tags every Ogg Opus bitstream this package writes. Each
+// encoded file is an independent stream, so reusing one serial across
+// files is harmless.
+const opusStreamSerial = 1
+
+// opusPreSkipSamples is the number of samples (at Opus's 48kHz clock) a
+// decoder should discard from the start of the stream to skip the
+// encoder's priming delay. This binding doesn't expose libopus's actual
+// reported look-ahead, so instead of guessing we report one full encoded
+// frame (opusSampleRate/50, i.e. 20ms) - a conservative upper bound on
+// real Opus algorithmic delay for any mode, rather than the RFC
+// 7845-mandated 0, which otherwise leaves a leading glitch in the
+// decoded audio.
+const opusPreSkipSamples = opusSampleRate / 50
+
+// oggCRCTable is the lookup table for the CRC-32 variant Ogg pages use
+// (polynomial 0x04c11db7, no reflection), per RFC 3533 section 5.
+var oggCRCTable = buildOggCRCTable()
+
+func buildOggCRCTable() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		crc := uint32(i) << 24
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x80000000 != 0 {
+				crc = (crc << 1) ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}
+
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc = (crc << 8) ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+// oggOpusWriter serializes Opus packets into a standard Ogg Opus
+// bitstream (RFC 7845): an OpusHead page, an OpusTags page, then one page
+// per audio packet carrying a running 48kHz granule position.
+type oggOpusWriter struct {
+	w       io.Writer
+	serial  uint32
+	pageSeq uint32
+	granule int64
+}
+
+func newOggOpusWriter(w io.Writer) *oggOpusWriter {
+	return &oggOpusWriter{w: w, serial: opusStreamSerial}
+}
+
+// WriteHeaders writes the OpusHead and OpusTags pages every Ogg Opus
+// stream must open with. inputSampleRate is informational only - it
+// records the original capture rate, even though Opus itself always
+// encodes at opusSampleRate.
+func (o *oggOpusWriter) WriteHeaders(channels, inputSampleRate int) error {
+	head := make([]byte, 0, 19)
+	head = append(head, "OpusHead"...)
+	head = append(head, 1) // version
+	head = append(head, byte(channels))
+	head = binary.LittleEndian.AppendUint16(head, opusPreSkipSamples) // pre-skip
+	head = binary.LittleEndian.AppendUint32(head, uint32(inputSampleRate))
+	head = binary.LittleEndian.AppendUint16(head, 0) // output gain
+	head = append(head, 0)                           // channel mapping family
+	if err := o.writePage(head, 0, true, false); err != nil {
+		return err
+	}
+
+	const vendor = "swear-by-sheets"
+	tags := make([]byte, 0, 16+len(vendor))
+	tags = append(tags, "OpusTags"...)
+	tags = binary.LittleEndian.AppendUint32(tags, uint32(len(vendor)))
+	tags = append(tags, vendor...)
+	tags = binary.LittleEndian.AppendUint32(tags, 0) // no user comments
+	return o.writePage(tags, 0, false, false)
+}
+
+// WritePacket wraps one encoded Opus packet in its own Ogg page, advancing
+// the granule position by frameSamples (measured at Opus's 48kHz clock).
+func (o *oggOpusWriter) WritePacket(packet []byte, frameSamples int, last bool) error {
+	o.granule += int64(frameSamples)
+	return o.writePage(packet, o.granule, false, last)
+}
+
+func (o *oggOpusWriter) writePage(payload []byte, granule int64, first, last bool) error {
+	segments := oggLacingValues(len(payload))
+
+	page := make([]byte, 0, 27+len(segments)+len(payload))
+	page = append(page, "OggS"...)
+	page = append(page, 0) // version
+
+	var headerType byte
+	if first {
+		headerType |= 0x02
+	}
+	if last {
+		headerType |= 0x04
+	}
+	page = append(page, headerType)
+
+	page = binary.LittleEndian.AppendUint64(page, uint64(granule))
+	page = binary.LittleEndian.AppendUint32(page, o.serial)
+	page = binary.LittleEndian.AppendUint32(page, o.pageSeq)
+	o.pageSeq++
+	page = binary.LittleEndian.AppendUint32(page, 0) // checksum, filled in below
+	page = append(page, byte(len(segments)))
+	page = append(page, segments...)
+	page = append(page, payload...)
+
+	binary.LittleEndian.PutUint32(page[22:26], oggCRC(page))
+
+	_, err := o.w.Write(page)
+	return err
+}
+
+// oggLacingValues computes the Ogg segment table for a payload of length
+// n: a run of 255s followed by a final value under 255 (exactly 0 if n is
+// a multiple of 255) that marks the packet boundary.
+func oggLacingValues(n int) []byte {
+	var segments []byte
+	for n >= 255 {
+		segments = append(segments, 255)
+		n -= 255
+	}
+	return append(segments, byte(n))
+}
@@ -0,0 +1,96 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestOggLacingValues(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []byte
+	}{
+		{0, []byte{0}},
+		{1, []byte{1}},
+		{254, []byte{254}},
+		{255, []byte{255, 0}},
+		{256, []byte{255, 1}},
+		{510, []byte{255, 255, 0}},
+		{765, []byte{255, 255, 255, 0}},
+	}
+	for _, tt := range tests {
+		if got := oggLacingValues(tt.n); !bytes.Equal(got, tt.want) {
+			t.Errorf("oggLacingValues(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestOggCRC(t *testing.T) {
+	// Known-answer values for the non-reflected CRC-32 variant Ogg pages
+	// use (RFC 3533 section 5), computed independently of oggCRCTable.
+	tests := []struct {
+		name string
+		data []byte
+		want uint32
+	}{
+		{"empty", nil, 0},
+		{"single zero byte", []byte{0x00}, 0},
+		{"digits", []byte("123456789"), 0x89a1897f},
+		{"OggS", []byte("OggS"), 0x5fb0a94f},
+	}
+	for _, tt := range tests {
+		if got := oggCRC(tt.data); got != tt.want {
+			t.Errorf("oggCRC(%s) = %#x, want %#x", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteHeadersOpusHeadFields(t *testing.T) {
+	var buf bytes.Buffer
+	ogg := newOggOpusWriter(&buf)
+	if err := ogg.WriteHeaders(1, 44100); err != nil {
+		t.Fatalf("WriteHeaders: %v", err)
+	}
+
+	out := buf.Bytes()
+	if len(out) < 27 || string(out[0:4]) != "OggS" {
+		t.Fatalf("expected output to start with an OggS page, got %v", out)
+	}
+
+	// The OpusHead payload starts right after the fixed 27-byte page
+	// header plus the single-segment lacing byte.
+	segmentTableLen := int(out[26])
+	payload := out[27+segmentTableLen:]
+	if string(payload[0:8]) != "OpusHead" {
+		t.Fatalf("expected OpusHead magic, got %q", payload[0:8])
+	}
+	if channels := payload[9]; channels != 1 {
+		t.Errorf("channel count = %d, want 1", channels)
+	}
+	preSkip := binary.LittleEndian.Uint16(payload[10:12])
+	if preSkip != opusPreSkipSamples {
+		t.Errorf("pre-skip = %d, want %d", preSkip, opusPreSkipSamples)
+	}
+	inputRate := binary.LittleEndian.Uint32(payload[12:16])
+	if inputRate != 44100 {
+		t.Errorf("input sample rate = %d, want 44100", inputRate)
+	}
+}
+
+func TestWritePacketAdvancesGranule(t *testing.T) {
+	var buf bytes.Buffer
+	ogg := newOggOpusWriter(&buf)
+	if err := ogg.WritePacket([]byte{1, 2, 3}, 960, false); err != nil {
+		t.Fatalf("WritePacket: %v", err)
+	}
+	if ogg.granule != 960 {
+		t.Errorf("granule after one packet = %d, want 960", ogg.granule)
+	}
+	if err := ogg.WritePacket([]byte{4, 5, 6}, 960, true); err != nil {
+		t.Fatalf("WritePacket: %v", err)
+	}
+	if ogg.granule != 1920 {
+		t.Errorf("granule after two packets = %d, want 1920", ogg.granule)
+	}
+}
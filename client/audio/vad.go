@@ -0,0 +1,159 @@
+package audio
+
+// VADConfig configures voice-activity-triggered recording.
+type VADConfig struct {
+	// Aggressiveness tunes the energy threshold used to classify a frame
+	// as speech: 0 (most permissive) through 3 (strictest, fewest false
+	// positives).
+	Aggressiveness int
+	// LeadingPadMs of audio captured before speech is detected is kept,
+	// so the start of an utterance isn't clipped.
+	LeadingPadMs int
+	// TrailingSilenceMs of continuous silence after speech ends before
+	// the utterance is considered finished.
+	TrailingSilenceMs int
+}
+
+// DefaultVADConfig returns reasonable defaults for short voice commands.
+func DefaultVADConfig() VADConfig {
+	return VADConfig{
+		Aggressiveness:    2,
+		LeadingPadMs:      300,
+		TrailingSilenceMs: 800,
+	}
+}
+
+// Speech event types emitted on Recorder.SpeechEvents.
+const (
+	SpeechStart = "speech_start"
+	SpeechEnd   = "speech_end"
+)
+
+// SpeechEvent reports a voice-activity transition detected while VAD is
+// enabled.
+type SpeechEvent struct {
+	Type string
+}
+
+// energyThresholds maps VADConfig.Aggressiveness (0-3) to a mean-square
+// energy threshold, normalized to full scale.
+var energyThresholds = [4]float64{0.0005, 0.001, 0.002, 0.004}
+
+// EnableVAD turns on voice-activity detection: while recording, frames
+// below the speech threshold are buffered as pre-roll instead of the
+// recording, and StopRecording isn't needed between utterances -
+// SpeechEvents reports speech start/end transitions instead.
+func (r *Recorder) EnableVAD(cfg VADConfig) {
+	r.mu.Lock()
+	r.vadEnabled = true
+	r.vadCfg = cfg
+	if r.vadEvents == nil {
+		r.vadEvents = make(chan SpeechEvent, 16)
+	}
+	r.mu.Unlock()
+}
+
+// DisableVAD turns off voice-activity detection; processAudio resumes
+// recording every captured frame unconditionally.
+func (r *Recorder) DisableVAD() {
+	r.mu.Lock()
+	r.vadEnabled = false
+	r.mu.Unlock()
+}
+
+// SpeechEvents reports speech start/end transitions detected while VAD
+// is enabled.
+func (r *Recorder) SpeechEvents() <-chan SpeechEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.vadEvents == nil {
+		r.vadEvents = make(chan SpeechEvent, 16)
+	}
+	return r.vadEvents
+}
+
+// processVADFrame classifies one captured frame as speech or silence,
+// keeping a LeadingPadMs pre-roll buffer while silent and appending to
+// the recording once speech starts. Callers must hold r.mu.
+func (r *Recorder) processVADFrame(frame []int16) {
+	isSpeech := isSpeechFrame(frameEnergy(frame), zeroCrossingRate(frame), r.vadCfg.Aggressiveness)
+	frameMs := float64(len(frame)) / float64(sampleRate) * 1000
+
+	if isSpeech {
+		r.vadSilenceMs = 0
+		if !r.vadSpeaking {
+			r.vadSpeaking = true
+			r.appendSamples(r.vadPad)
+			r.vadPad = nil
+			r.emitSpeechEvent(SpeechEvent{Type: SpeechStart})
+		}
+		r.appendSamples(frame)
+		return
+	}
+
+	if r.vadSpeaking {
+		r.appendSamples(frame)
+		r.vadSilenceMs += frameMs
+		if r.vadSilenceMs >= float64(r.vadCfg.TrailingSilenceMs) {
+			r.vadSpeaking = false
+			r.vadSilenceMs = 0
+			r.emitSpeechEvent(SpeechEvent{Type: SpeechEnd})
+		}
+		return
+	}
+
+	r.vadPad = append(r.vadPad, frame...)
+	maxPadSamples := int(float64(r.vadCfg.LeadingPadMs) / 1000 * float64(sampleRate))
+	if len(r.vadPad) > maxPadSamples {
+		r.vadPad = r.vadPad[len(r.vadPad)-maxPadSamples:]
+	}
+}
+
+// emitSpeechEvent sends ev to SpeechEvents, dropping it if the channel
+// is full rather than blocking the audio callback.
+func (r *Recorder) emitSpeechEvent(ev SpeechEvent) {
+	select {
+	case r.vadEvents <- ev:
+	default:
+	}
+}
+
+// frameEnergy returns the mean-square energy of frame, normalized to
+// [0, 1].
+func frameEnergy(frame []int16) float64 {
+	if len(frame) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range frame {
+		v := float64(s) / 32768.0
+		sum += v * v
+	}
+	return sum / float64(len(frame))
+}
+
+// zeroCrossingRate returns the fraction of adjacent sample pairs that
+// cross zero, used to distinguish tonal speech from broadband noise.
+func zeroCrossingRate(frame []int16) float64 {
+	if len(frame) < 2 {
+		return 0
+	}
+	crossings := 0
+	for i := 1; i < len(frame); i++ {
+		if (frame[i-1] >= 0) != (frame[i] >= 0) {
+			crossings++
+		}
+	}
+	return float64(crossings) / float64(len(frame)-1)
+}
+
+// isSpeechFrame classifies a frame as speech using an aggressiveness-
+// tuned energy threshold, rejecting high zero-crossing noise.
+func isSpeechFrame(energy, zcr float64, aggressiveness int) bool {
+	if aggressiveness < 0 {
+		aggressiveness = 0
+	} else if aggressiveness > 3 {
+		aggressiveness = 3
+	}
+	return energy >= energyThresholds[aggressiveness] && zcr < 0.5
+}
@@ -0,0 +1,66 @@
+package audio
+
+import "testing"
+
+func TestFrameEnergy(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []int16
+		want  float64
+	}{
+		{"empty", nil, 0},
+		{"silence", []int16{0, 0, 0, 0}, 0},
+		{"full scale", []int16{-32768, -32768, -32768, -32768}, 1},
+		{"half scale", []int16{16384, -16384}, 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frameEnergy(tt.frame); got != tt.want {
+				t.Errorf("frameEnergy(%v) = %v, want %v", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZeroCrossingRate(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []int16
+		want  float64
+	}{
+		{"too short", []int16{1}, 0},
+		{"no crossings", []int16{1, 2, 3, 4}, 0},
+		{"alternating", []int16{1, -1, 1, -1}, 1},
+		{"one crossing in three pairs", []int16{1, 1, -1, -1}, 1.0 / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zeroCrossingRate(tt.frame); got != tt.want {
+				t.Errorf("zeroCrossingRate(%v) = %v, want %v", tt.frame, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSpeechFrame(t *testing.T) {
+	tests := []struct {
+		name           string
+		energy, zcr    float64
+		aggressiveness int
+		want           bool
+	}{
+		{"loud tonal passes at aggressiveness 0", 0.001, 0.1, 0, true},
+		{"loud tonal fails at aggressiveness 3", 0.001, 0.1, 3, false},
+		{"loud but noisy (high zcr) rejected", 0.01, 0.6, 0, false},
+		{"quiet rejected at any aggressiveness", 0.0001, 0.1, 0, false},
+		{"aggressiveness clamped above range", 0.01, 0.1, 10, true},
+		{"aggressiveness clamped below range", 0.01, 0.1, -5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSpeechFrame(tt.energy, tt.zcr, tt.aggressiveness); got != tt.want {
+				t.Errorf("isSpeechFrame(%v, %v, %v) = %v, want %v", tt.energy, tt.zcr, tt.aggressiveness, got, tt.want)
+			}
+		})
+	}
+}
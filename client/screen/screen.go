@@ -3,6 +3,7 @@ package screen
 import (
 	"fmt"
 	"image"
+	"image/draw"
 	"image/png"
 	"os"
 	"sync"
@@ -13,11 +14,15 @@ import (
 
 // Recorder handles screen capture
 type Recorder struct {
-	latestImage *image.RGBA
-	recording   bool
-	mu          sync.Mutex
-	stopChan    chan bool
-	wg          sync.WaitGroup
+	latestImage     *image.RGBA
+	recording       bool
+	mu              sync.Mutex
+	stopChan        chan bool
+	wg              sync.WaitGroup
+	displayIndex    int
+	region          *image.Rectangle
+	captureAll      bool
+	autoCropFocused bool
 }
 
 // NewRecorder creates a new screen recorder
@@ -27,6 +32,99 @@ func NewRecorder() *Recorder {
 	}
 }
 
+// NumDisplays returns the number of active displays detected on this
+// machine.
+func NumDisplays() int {
+	return screenshot.NumActiveDisplays()
+}
+
+// SetDisplay selects which display captureScreen reads from. It has no
+// effect when CaptureAll mode is enabled.
+func (r *Recorder) SetDisplay(index int) error {
+	if index < 0 || index >= screenshot.NumActiveDisplays() {
+		return fmt.Errorf("display index %d out of range", index)
+	}
+	r.mu.Lock()
+	r.displayIndex = index
+	r.mu.Unlock()
+	return nil
+}
+
+// SetRegion restricts capture to a specific rectangle instead of the
+// whole display. Pass a zero Rectangle to capture the full display again.
+func (r *Recorder) SetRegion(region image.Rectangle) {
+	r.mu.Lock()
+	if region == (image.Rectangle{}) {
+		r.region = nil
+	} else {
+		r.region = &region
+	}
+	r.mu.Unlock()
+}
+
+// SetCaptureAllDisplays enables or disables capturing every active
+// display and stitching them into a single wide image, instead of only
+// the selected display.
+func (r *Recorder) SetCaptureAllDisplays(enabled bool) {
+	r.mu.Lock()
+	r.captureAll = enabled
+	r.mu.Unlock()
+}
+
+// SetAutoCropToFocusedWindow enables or disables auto-cropping captures
+// to the bounds of the currently focused window, reducing the
+// screenshot payload sent to the backend.
+func (r *Recorder) SetAutoCropToFocusedWindow(enabled bool) {
+	r.mu.Lock()
+	r.autoCropFocused = enabled
+	r.mu.Unlock()
+}
+
+// CaptureAll captures every active display and returns one image per
+// display, in display order.
+func (r *Recorder) CaptureAll() ([]*image.RGBA, error) {
+	n := screenshot.NumActiveDisplays()
+	images := make([]*image.RGBA, 0, n)
+	for i := 0; i < n; i++ {
+		img, err := screenshot.CaptureRect(screenshot.GetDisplayBounds(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture display %d: %v", i, err)
+		}
+		images = append(images, toRGBA(img))
+	}
+	return images, nil
+}
+
+// StitchHorizontal combines multiple display captures into a single wide
+// image, side by side in the order given.
+func StitchHorizontal(images []*image.RGBA) *image.RGBA {
+	if len(images) == 0 {
+		return nil
+	}
+	if len(images) == 1 {
+		return images[0]
+	}
+
+	totalWidth, maxHeight := 0, 0
+	for _, img := range images {
+		b := img.Bounds()
+		totalWidth += b.Dx()
+		if b.Dy() > maxHeight {
+			maxHeight = b.Dy()
+		}
+	}
+
+	stitched := image.NewRGBA(image.Rect(0, 0, totalWidth, maxHeight))
+	offsetX := 0
+	for _, img := range images {
+		b := img.Bounds()
+		dst := image.Rect(offsetX, 0, offsetX+b.Dx(), b.Dy())
+		draw.Draw(stitched, dst, img, b.Min, draw.Src)
+		offsetX += b.Dx()
+	}
+	return stitched
+}
+
 // StartRecording begins screen capture
 func (r *Recorder) StartRecording() error {
 	r.mu.Lock()
@@ -115,10 +213,36 @@ func (r *Recorder) captureLoop() {
 	}
 }
 
-// captureScreen captures a single screenshot
+// captureScreen captures a single screenshot, honoring the configured
+// display, region, multi-display, and focused-window settings.
 func (r *Recorder) captureScreen() {
-	// Get the bounds of the primary display
-	bounds := screenshot.GetDisplayBounds(0)
+	r.mu.Lock()
+	displayIndex := r.displayIndex
+	region := r.region
+	captureAll := r.captureAll
+	autoCropFocused := r.autoCropFocused
+	r.mu.Unlock()
+
+	if captureAll {
+		images, err := r.CaptureAll()
+		if err != nil {
+			fmt.Printf("Failed to capture screens: %v\n", err)
+			return
+		}
+		r.mu.Lock()
+		r.latestImage = StitchHorizontal(images)
+		r.mu.Unlock()
+		return
+	}
+
+	bounds := screenshot.GetDisplayBounds(displayIndex)
+	if autoCropFocused {
+		if focused, err := activeWindowBounds(); err == nil {
+			bounds = focused
+		}
+	} else if region != nil {
+		bounds = *region
+	}
 
 	// Capture the screen
 	img, err := screenshot.CaptureRect(bounds)
@@ -128,20 +252,22 @@ func (r *Recorder) captureScreen() {
 		return
 	}
 
-	// Convert to RGBA if needed
-	rgba := image.NewRGBA(img.Bounds())
-	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
-		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
-			rgba.Set(x, y, img.At(x, y))
-		}
-	}
-
 	// Update latest screenshot
 	r.mu.Lock()
-	r.latestImage = rgba
+	r.latestImage = toRGBA(img)
 	r.mu.Unlock()
 }
 
+// toRGBA converts img to *image.RGBA, copying pixel data if needed.
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, img.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba
+}
+
 // IsRecording returns whether the recorder is currently recording
 func (r *Recorder) IsRecording() bool {
 	r.mu.Lock()
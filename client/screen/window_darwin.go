@@ -0,0 +1,72 @@
+//go:build darwin
+
+package screen
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics -framework CoreFoundation
+#include <CoreGraphics/CoreGraphics.h>
+#include <CoreFoundation/CoreFoundation.h>
+
+// frontmostWindowBounds finds the topmost on-screen window (layer 0,
+// i.e. an ordinary app window rather than menu bar/dock chrome) and
+// writes its screen-space bounds into x/y/w/h. Returns 0 on success.
+static int frontmostWindowBounds(int *x, int *y, int *w, int *h) {
+	CFArrayRef list = CGWindowListCopyWindowInfo(
+		kCGWindowListOptionOnScreenOnly | kCGWindowListExcludeDesktopElements,
+		kCGNullWindowID);
+	if (list == NULL) {
+		return -1;
+	}
+
+	int found = -1;
+	CFIndex count = CFArrayGetCount(list);
+	for (CFIndex i = 0; i < count; i++) {
+		CFDictionaryRef info = (CFDictionaryRef)CFArrayGetValueAtIndex(list, i);
+
+		CFNumberRef layerNum = (CFNumberRef)CFDictionaryGetValue(info, kCGWindowLayer);
+		int layer = -1;
+		if (layerNum) {
+			CFNumberGetValue(layerNum, kCFNumberIntType, &layer);
+		}
+		if (layer != 0) {
+			continue;
+		}
+
+		CFDictionaryRef bounds = (CFDictionaryRef)CFDictionaryGetValue(info, kCGWindowBounds);
+		if (bounds == NULL) {
+			continue;
+		}
+
+		CGRect rect;
+		if (!CGRectMakeWithDictionaryRepresentation(bounds, &rect)) {
+			continue;
+		}
+
+		*x = (int)rect.origin.x;
+		*y = (int)rect.origin.y;
+		*w = (int)rect.size.width;
+		*h = (int)rect.size.height;
+		found = 0;
+		break;
+	}
+
+	CFRelease(list);
+	return found;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"image"
+)
+
+// activeWindowBounds returns the bounds of the frontmost on-screen
+// window via the CGWindowListCopyWindowInfo Quartz Window Services API.
+func activeWindowBounds() (image.Rectangle, error) {
+	var x, y, w, h C.int
+	if C.frontmostWindowBounds(&x, &y, &w, &h) != 0 {
+		return image.Rectangle{}, fmt.Errorf("no focused window found")
+	}
+	return image.Rect(int(x), int(y), int(x+w), int(y+h)), nil
+}
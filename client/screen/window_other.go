@@ -0,0 +1,14 @@
+//go:build !darwin && !windows
+
+package screen
+
+import (
+	"fmt"
+	"image"
+)
+
+// activeWindowBounds reports that active-window detection has no
+// implementation on this platform.
+func activeWindowBounds() (image.Rectangle, error) {
+	return image.Rectangle{}, fmt.Errorf("active window detection is not supported on this platform")
+}
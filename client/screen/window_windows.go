@@ -0,0 +1,37 @@
+//go:build windows
+
+package screen
+
+import (
+	"fmt"
+	"image"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	user32                  = syscall.NewLazyDLL("user32.dll")
+	procGetForegroundWindow = user32.NewProc("GetForegroundWindow")
+	procGetWindowRect       = user32.NewProc("GetWindowRect")
+)
+
+type win32Rect struct {
+	Left, Top, Right, Bottom int32
+}
+
+// activeWindowBounds returns the bounds of the foreground window via
+// GetForegroundWindow/GetWindowRect.
+func activeWindowBounds() (image.Rectangle, error) {
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 {
+		return image.Rectangle{}, fmt.Errorf("no foreground window found")
+	}
+
+	var rect win32Rect
+	ret, _, err := procGetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&rect)))
+	if ret == 0 {
+		return image.Rectangle{}, fmt.Errorf("GetWindowRect failed: %v", err)
+	}
+
+	return image.Rect(int(rect.Left), int(rect.Top), int(rect.Right), int(rect.Bottom)), nil
+}
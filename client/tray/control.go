@@ -0,0 +1,190 @@
+package tray
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+)
+
+const defaultControlPort = "8765"
+
+// controlEvent is broadcast to GET /events subscribers as state changes
+// and backend responses arrive.
+type controlEvent struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+// recordingStatus is the payload for GET /recording/status.
+type recordingStatus struct {
+	Recording     bool  `json:"recording"`
+	DurationMs    int64 `json:"durationMs"`
+	HasScreenshot bool  `json:"hasScreenshot"`
+}
+
+// startControlServer starts a loopback-only HTTP server that lets
+// external tools (Stream Deck, hotkey daemons, editor plugins) drive the
+// recorder without the systray menu.
+func (t *Tray) startControlServer() {
+	port := defaultControlPort
+	if envPort := os.Getenv("SWEAR_BY_SHEETS_CONTROL_PORT"); envPort != "" {
+		port = envPort
+	}
+
+	t.controlSubs = make(map[chan controlEvent]struct{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/recording/start", t.handleRecordingStart)
+	mux.HandleFunc("/recording/stop", t.handleRecordingStop)
+	mux.HandleFunc("/recording/status", t.handleRecordingStatus)
+	mux.HandleFunc("/recordings", t.handleRecordingsList)
+	mux.HandleFunc("/events", t.handleEvents)
+
+	addr := "127.0.0.1:" + port
+	t.controlServer = &http.Server{
+		Addr:    addr,
+		Handler: loopbackOnly(mux),
+	}
+
+	go func() {
+		log.Printf("Control API listening on %s", addr)
+		if err := t.controlServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Control API server stopped: %v", err)
+		}
+	}()
+}
+
+// loopbackOnly rejects requests whose remote address isn't localhost, so
+// the control API can't be driven from the network even if the bind
+// address is ever widened by mistake.
+func loopbackOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (t *Tray) handleRecordingStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !t.startRecording() {
+		http.Error(w, "already recording", http.StatusConflict)
+		return
+	}
+	writeJSON(w, recordingStatus{Recording: true})
+}
+
+func (t *Tray) handleRecordingStop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !t.stopRecording() {
+		http.Error(w, "not recording", http.StatusConflict)
+		return
+	}
+	writeJSON(w, recordingStatus{Recording: false})
+}
+
+func (t *Tray) handleRecordingStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, recordingStatus{
+		Recording:     t.isRecordingNow(),
+		DurationMs:    t.audioRecorder.GetDuration().Milliseconds(),
+		HasScreenshot: t.screenRecorder.HasScreenshot(),
+	})
+}
+
+func (t *Tray) handleRecordingsList(w http.ResponseWriter, r *http.Request) {
+	entries, err := os.ReadDir(t.recordingsDir)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list recordings: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	writeJSON(w, names)
+}
+
+// handleEvents streams state transitions and backend results as
+// server-sent events until the client disconnects.
+func (t *Tray) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	sub := t.subscribeEvents()
+	defer t.unsubscribeEvents(sub)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-sub:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+func (t *Tray) subscribeEvents() chan controlEvent {
+	sub := make(chan controlEvent, 16)
+	t.controlSubsMu.Lock()
+	t.controlSubs[sub] = struct{}{}
+	t.controlSubsMu.Unlock()
+	return sub
+}
+
+func (t *Tray) unsubscribeEvents(sub chan controlEvent) {
+	t.controlSubsMu.Lock()
+	delete(t.controlSubs, sub)
+	t.controlSubsMu.Unlock()
+	close(sub)
+}
+
+// broadcastEvent fans an event out to every GET /events subscriber,
+// dropping it for subscribers whose buffer is full rather than blocking.
+func (t *Tray) broadcastEvent(event controlEvent) {
+	t.controlSubsMu.Lock()
+	defer t.controlSubsMu.Unlock()
+	for sub := range t.controlSubs {
+		select {
+		case sub <- event:
+		default:
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+}
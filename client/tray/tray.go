@@ -1,11 +1,14 @@
 package tray
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/chtushar/swear-by-sheets/client/api"
@@ -17,7 +20,12 @@ import (
 
 // Tray struct
 type Tray struct {
-	ctx            context.Context
+	ctx context.Context
+
+	// recordingMu serializes startRecording/stopRecording so concurrent
+	// callers (systray clicks, the control API, and the hands-free loop)
+	// can't race on isRecording/streamSession/streamCancel.
+	recordingMu    sync.Mutex
 	isRecording    bool
 	toggleMenuItem *systray.MenuItem
 	quitMenuItem   *systray.MenuItem
@@ -25,6 +33,23 @@ type Tray struct {
 	screenRecorder *screen.Recorder
 	apiClient      *api.Client
 	recordingsDir  string
+
+	streamAudio   bool
+	streamSession *api.StreamSession
+	streamCancel  context.CancelFunc
+	streamDone    chan struct{}
+
+	controlServer *http.Server
+	controlSubs   map[chan controlEvent]struct{}
+	controlSubsMu sync.Mutex
+
+	handsFreeMenuItem *systray.MenuItem
+	// handsFreeActive and handsFreeDone are guarded by recordingMu: the
+	// flag is read from the hands-free loop goroutine and written from
+	// systray click handlers, and the done channel gives the loop an
+	// exit signal so stopHandsFree/startHandsFree cycles don't leak one.
+	handsFreeActive bool
+	handsFreeDone   chan struct{}
 }
 
 // New creates a new Tray application struct
@@ -39,13 +64,23 @@ func (t *Tray) Startup(ctx context.Context) error {
 
 	// Initialize audio recorder
 	t.audioRecorder = audio.NewRecorder()
+	if codec := os.Getenv("SWEAR_BY_SHEETS_AUDIO_CODEC"); codec != "" {
+		if err := t.audioRecorder.SetCodec(audio.Codec(codec)); err != nil {
+			return fmt.Errorf("invalid SWEAR_BY_SHEETS_AUDIO_CODEC: %v", err)
+		}
+	}
 	// Initialize screen recorder
 	t.screenRecorder = screen.NewRecorder()
+	if os.Getenv("SWEAR_BY_SHEETS_AUTO_CROP_FOCUSED_WINDOW") == "1" {
+		t.screenRecorder.SetAutoCropToFocusedWindow(true)
+	}
 	apiURL := "http://localhost:8787"
 	if envURL := os.Getenv("SWEAR_BY_SHEETS_API_URL"); envURL != "" {
 		apiURL = envURL
 	}
 	t.apiClient = api.NewClient(apiURL)
+	t.streamAudio = os.Getenv("SWEAR_BY_SHEETS_STREAM_AUDIO") == "1"
+	t.audioRecorder.SetSegmentsEnabled(t.streamAudio)
 
 	// Create recordings directory
 	homeDir, err := os.UserHomeDir()
@@ -57,8 +92,7 @@ func (t *Tray) Startup(ctx context.Context) error {
 		return fmt.Errorf("failed to create recordings directory: %v", err)
 	}
 
-	// TODO: Initialize other dependencies
-	// - HTTP client
+	t.startControlServer()
 
 	systray.Run(t.onReady, t.onExit)
 	return nil
@@ -69,6 +103,8 @@ func (t *Tray) onReady() {
 	systray.SetTooltip("Voice + Screen control for Google Sheets")
 
 	t.setupToggleRecording()
+	t.setupDisplayMenu()
+	t.setupHandsFree()
 	systray.AddSeparator()
 	t.setupQuit()
 }
@@ -91,7 +127,7 @@ func (t *Tray) setupToggleRecording() {
 		for {
 			select {
 			case <-t.toggleMenuItem.ClickedCh:
-				if t.isRecording {
+				if t.isRecordingNow() {
 					t.stopRecording()
 				} else {
 					t.startRecording()
@@ -101,20 +137,219 @@ func (t *Tray) setupToggleRecording() {
 	}()
 }
 
+// setupDisplayMenu builds a "Choose display" submenu from the active
+// displays, plus a checkbox to capture and stitch all of them together.
+// Exactly one of the per-display items or "All Displays" is checked at a
+// time, so selecting one unchecks every sibling.
+func (t *Tray) setupDisplayMenu() {
+	n := screen.NumDisplays()
+	if n <= 1 {
+		return
+	}
+
+	displayMenu := systray.AddMenuItem("Choose Display", "Select which display to record")
+
+	items := make([]*systray.MenuItem, n)
+	for i := 0; i < n; i++ {
+		items[i] = displayMenu.AddSubMenuItemCheckbox(fmt.Sprintf("Display %d", i+1), "", i == 0)
+	}
+	allItem := displayMenu.AddSubMenuItemCheckbox("All Displays", "Capture and stitch every display", false)
+
+	// uncheckAllBut checks selected and unchecks every other item in the
+	// submenu, so the group behaves as a single-select radio.
+	uncheckAllBut := func(selected *systray.MenuItem) {
+		for _, item := range items {
+			if item == selected {
+				item.Check()
+			} else {
+				item.Uncheck()
+			}
+		}
+		if allItem == selected {
+			allItem.Check()
+		} else {
+			allItem.Uncheck()
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		index := i
+		item := items[i]
+		go func() {
+			for range item.ClickedCh {
+				if err := t.screenRecorder.SetDisplay(index); err != nil {
+					log.Printf("Failed to set display: %v", err)
+					continue
+				}
+				t.screenRecorder.SetCaptureAllDisplays(false)
+				uncheckAllBut(item)
+			}
+		}()
+	}
+
+	go func() {
+		for range allItem.ClickedCh {
+			t.screenRecorder.SetCaptureAllDisplays(true)
+			uncheckAllBut(allItem)
+		}
+	}()
+}
+
+// setupHandsFree adds a "Hands-free mode" toggle that keeps the mic hot
+// and fires ProcessAudioFile per detected utterance, without requiring
+// the user to click the tray for every command.
+func (t *Tray) setupHandsFree() {
+	t.handsFreeMenuItem = systray.AddMenuItemCheckbox("Hands-free mode", "Continuously listen and auto-invoke on speech", false)
+	go func() {
+		for range t.handsFreeMenuItem.ClickedCh {
+			if t.handsFreeMenuItem.Checked() {
+				t.handsFreeMenuItem.Uncheck()
+				t.stopHandsFree()
+			} else {
+				t.handsFreeMenuItem.Check()
+				t.startHandsFree()
+			}
+		}
+	}()
+}
+
+// startHandsFree enables VAD and opens the audio/screen stream once for
+// the whole hands-free session, keeping the mic hot. Each detected
+// utterance is processed independently by processUtterance, which only
+// resets the recorder's logical sample buffer - the stream itself stays
+// open until stopHandsFree.
+func (t *Tray) startHandsFree() {
+	t.audioRecorder.EnableVAD(audio.DefaultVADConfig())
+	t.setHandsFreeActive(true)
+
+	if !t.startRecording() {
+		t.setHandsFreeActive(false)
+		t.audioRecorder.DisableVAD()
+		return
+	}
+
+	done := make(chan struct{})
+	t.handsFreeDone = done
+	go t.runHandsFreeLoop(done)
+}
+
+// stopHandsFree disables VAD, stops the hands-free loop, and tears down
+// the recording session that's been open for the whole session.
+func (t *Tray) stopHandsFree() {
+	t.setHandsFreeActive(false)
+	t.audioRecorder.DisableVAD()
+	if t.handsFreeDone != nil {
+		close(t.handsFreeDone)
+		t.handsFreeDone = nil
+	}
+	t.stopRecording()
+}
+
+// runHandsFreeLoop calls processUtterance for every detected utterance
+// end, without touching the stream, until done is closed.
+func (t *Tray) runHandsFreeLoop(done <-chan struct{}) {
+	events := t.audioRecorder.SpeechEvents()
+	for {
+		select {
+		case <-done:
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if ev.Type == audio.SpeechEnd && t.isHandsFreeActive() {
+				t.processUtterance()
+			}
+		}
+	}
+}
+
+// processUtterance saves and uploads one hands-free utterance without
+// stopping the audio/screen stream, so the mic stays hot between
+// utterances.
+func (t *Tray) processUtterance() {
+	samples := t.audioRecorder.TakeUtterance()
+	if len(samples) == 0 {
+		return
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	audioFile := filepath.Join(t.recordingsDir, fmt.Sprintf("audio_%s.%s", timestamp, t.audioRecorder.Extension()))
+	if err := t.audioRecorder.SaveSamplesToFile(samples, audioFile); err != nil {
+		log.Printf("Failed to save audio file: %v", err)
+		return
+	}
+	log.Printf("Audio saved to: %s", audioFile)
+
+	var screenshotFile string
+	if t.screenRecorder.HasScreenshot() {
+		screenshotFile = filepath.Join(t.recordingsDir, fmt.Sprintf("screenshot_%s.png", timestamp))
+		if err := t.screenRecorder.SaveToFile(screenshotFile); err != nil {
+			log.Printf("Failed to save screenshot: %v", err)
+		} else {
+			log.Printf("Screenshot saved to: %s", screenshotFile)
+		}
+	}
+
+	systray.SetTooltip("Processing audio and screenshot...")
+	go t.processAndUpload(audioFile, screenshotFile)
+}
+
+// setHandsFreeActive sets handsFreeActive under recordingMu so it can be
+// read safely from the hands-free loop goroutine.
+func (t *Tray) setHandsFreeActive(active bool) {
+	t.recordingMu.Lock()
+	t.handsFreeActive = active
+	t.recordingMu.Unlock()
+}
+
+// isHandsFreeActive reports whether hands-free mode is active.
+func (t *Tray) isHandsFreeActive() bool {
+	t.recordingMu.Lock()
+	defer t.recordingMu.Unlock()
+	return t.handsFreeActive
+}
+
+// isRecordingNow reports whether a recording is currently in progress,
+// guarded by recordingMu since isRecording is written from startRecording/
+// stopRecording and read concurrently from the systray, onExit, and the
+// control API.
+func (t *Tray) isRecordingNow() bool {
+	t.recordingMu.Lock()
+	defer t.recordingMu.Unlock()
+	return t.isRecording
+}
+
 func (t *Tray) onExit() {
 	// Cleanup resources here
-	if t.isRecording {
+	if t.isHandsFreeActive() {
+		t.stopHandsFree()
+	} else if t.isRecordingNow() {
 		t.stopRecording()
 	}
+	if t.controlServer != nil {
+		if err := t.controlServer.Close(); err != nil {
+			log.Printf("Failed to close control server: %v", err)
+		}
+	}
 	log.Println("Application exiting...")
 }
 
-func (t *Tray) startRecording() {
+// startRecording starts audio/screen recording, reporting false instead
+// of starting a second time if a recording is already in progress.
+func (t *Tray) startRecording() bool {
+	t.recordingMu.Lock()
+	defer t.recordingMu.Unlock()
+
+	if t.isRecording {
+		return false
+	}
+
 	// Start audio recording
 	if err := t.audioRecorder.StartRecording(); err != nil {
 		log.Printf("Failed to start audio recording: %v", err)
 		systray.SetTooltip(fmt.Sprintf("Failed to start recording: %v", err))
-		return
+		return false
 	}
 
 	// Start screen recording
@@ -123,19 +358,116 @@ func (t *Tray) startRecording() {
 		// Continue with audio only if screen fails
 	}
 
+	if t.streamAudio {
+		t.startStreaming()
+	}
+
 	t.isRecording = true
 	t.toggleMenuItem.SetTitle("Stop Recording")
 	systray.SetTooltip("Recording in progress...")
+	t.broadcastEvent(controlEvent{Type: "recording_started"})
 
 	log.Println("Recording started")
+	return true
+}
+
+// startStreaming opens a StreamSession and forwards audio segments to the
+// backend as they are captured, instead of waiting for stopRecording to
+// upload the full recording.
+func (t *Tray) startStreaming() {
+	ctx, cancel := context.WithCancel(t.ctx)
+
+	session, err := t.apiClient.StreamAudio(ctx)
+	if err != nil {
+		log.Printf("Failed to open stream session: %v", err)
+		cancel()
+		return
+	}
+
+	t.streamSession = session
+	t.streamCancel = cancel
+	t.streamDone = make(chan struct{})
+
+	go func() {
+		defer close(t.streamDone)
+		t.forwardSegments(ctx, session)
+	}()
+	go t.logStreamDeltas(session)
 }
 
-func (t *Tray) stopRecording() {
+// forwardSegments encodes and forwards each segment emitted by the audio
+// recorder to session until ctx is cancelled.
+func (t *Tray) forwardSegments(ctx context.Context, session *api.StreamSession) {
+	enc, err := audio.NewEncoder(t.audioRecorder.Codec())
+	if err != nil {
+		log.Printf("Failed to create segment encoder: %v", err)
+		return
+	}
+
+	segments := t.audioRecorder.Segments()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case seg, ok := <-segments:
+			if !ok {
+				return
+			}
+			buf := new(bytes.Buffer)
+			if err := enc.Encode(buf, seg, t.audioRecorder.SampleRate(), t.audioRecorder.Channels()); err != nil {
+				log.Printf("Failed to encode segment: %v", err)
+				continue
+			}
+			if err := session.SendSegment(buf.Bytes()); err != nil {
+				log.Printf("Failed to send segment: %v", err)
+			}
+		}
+	}
+}
+
+// logStreamDeltas logs transcript deltas as the backend streams them back.
+func (t *Tray) logStreamDeltas(session *api.StreamSession) {
+	for delta := range session.Deltas() {
+		log.Printf("Transcript delta: %s", delta)
+	}
+}
+
+// stopRecording stops audio/screen recording and uploads/streams the
+// result, reporting false instead of stopping again if nothing is
+// currently recording.
+func (t *Tray) stopRecording() bool {
+	t.recordingMu.Lock()
+	defer t.recordingMu.Unlock()
+
+	if !t.isRecording {
+		return false
+	}
+
 	// Stop audio recording
 	if err := t.audioRecorder.StopRecording(); err != nil {
 		log.Printf("Failed to stop audio recording: %v", err)
 	}
 
+	// didStream records whether a StreamSession was actually opened for
+	// this recording, since t.streamAudio alone only says streaming was
+	// configured at startup - startStreaming can still fail to open a
+	// session (e.g. the backend was briefly unreachable), and the
+	// recording must fall back to processAndUpload in that case.
+	didStream := t.streamSession != nil
+	if didStream {
+		t.streamCancel()
+		// Wait for forwardSegments to observe the cancellation and return
+		// before closing, so Close's end-of-stream frame can't race a
+		// SendSegment call still in flight.
+		<-t.streamDone
+		if err := t.streamSession.Close(); err != nil {
+			log.Printf("Failed to close stream session: %v", err)
+		}
+		t.streamSession = nil
+		t.streamCancel = nil
+		t.streamDone = nil
+	}
+
 	// Stop screen recording
 	if err := t.screenRecorder.StopRecording(); err != nil {
 		log.Printf("Failed to stop screen recording: %v", err)
@@ -143,7 +475,7 @@ func (t *Tray) stopRecording() {
 
 	// Save audio file
 	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	audioFile := filepath.Join(t.recordingsDir, fmt.Sprintf("audio_%s.wav", timestamp))
+	audioFile := filepath.Join(t.recordingsDir, fmt.Sprintf("audio_%s.%s", timestamp, t.audioRecorder.Extension()))
 	if err := t.audioRecorder.SaveToFile(audioFile); err != nil {
 		log.Printf("Failed to save audio file: %v", err)
 		systray.SetTooltip(fmt.Sprintf("Failed to save recording: %v", err))
@@ -162,40 +494,58 @@ func (t *Tray) stopRecording() {
 		}
 	}
 
+	if didStream {
+		// Audio was already streamed segment-by-segment; the transcript
+		// arrived via stream deltas, so there's nothing left to upload.
+		t.isRecording = false
+		t.toggleMenuItem.SetTitle("Start Recording")
+		t.broadcastEvent(controlEvent{Type: "recording_stopped"})
+		log.Println("Recording stopped")
+		return true
+	}
+
 	systray.SetTooltip("Processing audio and screenshot...")
 
 	// Send audio and screenshot to backend for processing
-	go func() {
-		log.Println("Sending audio and screenshot to backend...")
-		resp, err := t.apiClient.ProcessAudioFile(audioFile, screenshotFile)
-		if err != nil {
-			log.Printf("Failed to process audio: %v", err)
-			systray.SetTooltip(fmt.Sprintf("Failed to process: %v", err))
-			return
-		}
-
-		if resp.Success {
-			log.Printf("Audio processed successfully")
-			systray.SetTooltip("Command executed successfully!")
-
-			// Log the response details if available
-			if resp.Transcript != "" {
-				log.Printf("Transcript: %s", resp.Transcript)
-			}
-			if resp.Command != nil {
-				log.Printf("Command: %v", resp.Command)
-			}
-			if resp.Result != nil {
-				log.Printf("Result: %v", resp.Result)
-			}
-		} else {
-			log.Printf("Processing failed: %s", resp.Error)
-			systray.SetTooltip(fmt.Sprintf("Processing failed: %s", resp.Error))
-		}
-	}()
+	go t.processAndUpload(audioFile, screenshotFile)
 
 	t.isRecording = false
 	t.toggleMenuItem.SetTitle("Start Recording")
+	t.broadcastEvent(controlEvent{Type: "recording_stopped"})
 
 	log.Println("Recording stopped")
+	return true
+}
+
+// processAndUpload sends audioFile and screenshotFile (if any) to the
+// backend and reports the result, shared by stopRecording and
+// hands-free's processUtterance.
+func (t *Tray) processAndUpload(audioFile, screenshotFile string) {
+	log.Println("Sending audio and screenshot to backend...")
+	resp, err := t.apiClient.ProcessAudioFile(audioFile, screenshotFile)
+	if err != nil {
+		log.Printf("Failed to process audio: %v", err)
+		systray.SetTooltip(fmt.Sprintf("Failed to process: %v", err))
+		return
+	}
+	t.broadcastEvent(controlEvent{Type: "process_result", Data: resp})
+
+	if resp.Success {
+		log.Printf("Audio processed successfully")
+		systray.SetTooltip("Command executed successfully!")
+
+		// Log the response details if available
+		if resp.Transcript != "" {
+			log.Printf("Transcript: %s", resp.Transcript)
+		}
+		if resp.Command != nil {
+			log.Printf("Command: %v", resp.Command)
+		}
+		if resp.Result != nil {
+			log.Printf("Result: %v", resp.Result)
+		}
+	} else {
+		log.Printf("Processing failed: %s", resp.Error)
+		systray.SetTooltip(fmt.Sprintf("Processing failed: %s", resp.Error))
+	}
 }